@@ -0,0 +1,287 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// broadcaster fans a stream of already-marshaled JSON messages out to any
+// number of subscribers, dropping messages for a subscriber that can't keep
+// up rather than blocking the publisher.
+type broadcaster struct {
+	mu      sync.Mutex
+	clients map[chan []byte]struct{}
+}
+
+func newBroadcaster() *broadcaster {
+	return &broadcaster{clients: make(map[chan []byte]struct{})}
+}
+
+func (b *broadcaster) subscribe() chan []byte {
+	ch := make(chan []byte, 16)
+	b.mu.Lock()
+	b.clients[ch] = struct{}{}
+	b.mu.Unlock()
+	return ch
+}
+
+func (b *broadcaster) unsubscribe(ch chan []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.clients[ch]; ok {
+		delete(b.clients, ch)
+		close(ch)
+	}
+}
+
+func (b *broadcaster) publish(data []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.clients {
+		select {
+		case ch <- data:
+		default:
+			// Slow client; drop the message rather than block every other
+			// subscriber on it.
+		}
+	}
+}
+
+// APIServer exposes the scraper's live state as a local HTTP API: JSON
+// snapshots of whatever the last LeagueData/GameData update contained, a
+// Server-Sent Events stream of every decoded update, and a /ws endpoint that
+// rebroadcasts the same updates as plain JSON with the Socket.IO "42[...]"
+// envelope already stripped. It is itself a Subscriber, so wiring it into an
+// EventBus is all that's needed to keep it current.
+type APIServer struct {
+	mu     sync.RWMutex
+	league *LeagueData
+	game   *GameData
+
+	broadcast *broadcaster
+	upgrader  websocket.Upgrader
+	logger    *slog.Logger
+}
+
+// NewAPIServer returns an APIServer with no state yet (populated as updates
+// arrive via the Subscriber methods). A nil logger falls back to slog.Default().
+func NewAPIServer(logger *slog.Logger) *APIServer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &APIServer{
+		broadcast: newBroadcaster(),
+		upgrader: websocket.Upgrader{
+			// This is a local read-only API in front of the upstream feed, not
+			// a privileged endpoint, so any origin may connect.
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+		logger: logger,
+	}
+}
+
+func (a *APIServer) OnLeagueData(data *LeagueData) {
+	a.mu.Lock()
+	a.league = data
+	a.mu.Unlock()
+	a.publish("leagueData", data)
+}
+
+func (a *APIServer) OnGameData(data *GameData) {
+	a.mu.Lock()
+	a.game = data
+	a.mu.Unlock()
+	a.publish("gameData", data)
+}
+
+func (a *APIServer) OnGameEvent(event *GameEvent) {
+	a.publish("gameEvent", event)
+}
+
+func (a *APIServer) publish(recordType string, data interface{}) {
+	payload, err := json.Marshal(jsonlRecord{Type: recordType, Time: time.Now(), Data: data})
+	if err != nil {
+		a.logger.Error("marshaling update for broadcast", "error", err, "update_type", recordType)
+		return
+	}
+	a.broadcast.publish(payload)
+}
+
+// Handler builds the mux of routes this server responds to.
+func (a *APIServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/teams", a.handleTeams)
+	mux.HandleFunc("/teams/", a.handleTeam)
+	mux.HandleFunc("/divisions", a.handleDivisions)
+	mux.HandleFunc("/leagues", a.handleLeagues)
+	mux.HandleFunc("/games/today", a.handleGamesToday)
+	mux.HandleFunc("/games/tomorrow", a.handleGamesTomorrow)
+	mux.HandleFunc("/standings", a.handleStandings)
+	mux.HandleFunc("/sim", a.handleSim)
+	mux.HandleFunc("/events", a.handleEvents)
+	mux.HandleFunc("/ws", a.handleWS)
+	mux.Handle("/metrics", promhttp.Handler())
+	return mux
+}
+
+func (a *APIServer) writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		a.logger.Error("encoding API response", "error", err)
+	}
+}
+
+func (a *APIServer) handleTeams(w http.ResponseWriter, r *http.Request) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.league == nil {
+		a.writeJSON(w, []*Team{})
+		return
+	}
+	a.writeJSON(w, a.league.Teams)
+}
+
+func (a *APIServer) handleTeam(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/teams/")
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.league != nil {
+		for _, team := range a.league.Teams {
+			if team.ID == id {
+				a.writeJSON(w, team)
+				return
+			}
+		}
+	}
+
+	http.NotFound(w, r)
+}
+
+func (a *APIServer) handleDivisions(w http.ResponseWriter, r *http.Request) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.league == nil {
+		a.writeJSON(w, []*Division{})
+		return
+	}
+	a.writeJSON(w, a.league.Divisions)
+}
+
+func (a *APIServer) handleLeagues(w http.ResponseWriter, r *http.Request) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.league == nil {
+		a.writeJSON(w, []*League{})
+		return
+	}
+	a.writeJSON(w, a.league.Leagues)
+}
+
+func (a *APIServer) handleGamesToday(w http.ResponseWriter, r *http.Request) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.game == nil {
+		a.writeJSON(w, []*Schedule{})
+		return
+	}
+	a.writeJSON(w, a.game.Schedules)
+}
+
+func (a *APIServer) handleGamesTomorrow(w http.ResponseWriter, r *http.Request) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.game == nil {
+		a.writeJSON(w, []*TomorrowSchedule{})
+		return
+	}
+	a.writeJSON(w, a.game.TomorrowsSchedules)
+}
+
+func (a *APIServer) handleStandings(w http.ResponseWriter, r *http.Request) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.game == nil {
+		a.writeJSON(w, nil)
+		return
+	}
+	a.writeJSON(w, a.game.Standsings)
+}
+
+func (a *APIServer) handleSim(w http.ResponseWriter, r *http.Request) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.game == nil {
+		a.writeJSON(w, nil)
+		return
+	}
+	a.writeJSON(w, a.game.Sim)
+}
+
+// handleEvents streams every decoded update to the client as Server-Sent
+// Events, one "data:" line of JSON per update, until the client disconnects.
+func (a *APIServer) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ch := a.broadcast.subscribe()
+	defer a.broadcast.unsubscribe(ch)
+
+	for {
+		select {
+		case payload, ok := <-ch:
+			if !ok {
+				return
+			}
+			fmt.Fprintf(w, "data: %s\n\n", payload)
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// handleWS upgrades the request to a websocket and rebroadcasts every
+// decoded update as plain JSON text frames, i.e. the same payload /events
+// sends, with none of the upstream Socket.IO "42[...]" framing.
+func (a *APIServer) handleWS(w http.ResponseWriter, r *http.Request) {
+	conn, err := a.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		a.logger.Error("websocket upgrade failed", "error", err)
+		return
+	}
+	defer conn.Close()
+
+	ch := a.broadcast.subscribe()
+	defer a.broadcast.unsubscribe(ch)
+
+	for payload := range ch {
+		if err := conn.WriteMessage(websocket.TextMessage, payload); err != nil {
+			return
+		}
+	}
+}
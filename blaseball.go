@@ -5,8 +5,11 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
@@ -240,17 +243,27 @@ type BlaseballWebSocket struct {
 	conn *websocket.Conn
 }
 
-func blaseballConnect(ctx context.Context, cookie string) (*BlaseballWebSocket, error) {
+// blaseballWebSocketURL is the Socket.IO/Engine.IO endpoint dialed by
+// blaseballConnect. It's a var (rather than inlined) so tests can point it at
+// a fake server.
+var blaseballWebSocketURL = "wss://blaseball.com/socket.io/?EIO=3&transport=websocket"
+
+func blaseballConnect(ctx context.Context, cookie string, logger *slog.Logger) (*BlaseballWebSocket, error) {
+	logger = loggerOrDefault(logger)
+
 	header := http.Header{}
 	header.Set("Cookie", cookie)
 
-	conn, resp, err := websocket.DefaultDialer.DialContext(ctx, "wss://blaseball.com/socket.io/?EIO=3&transport=websocket", header)
+	conn, resp, err := websocket.DefaultDialer.DialContext(ctx, blaseballWebSocketURL, header)
 	if err != nil {
-		fmt.Println(resp)
-		if body, err := ioutil.ReadAll(resp.Body); err != nil {
-			fmt.Println(err)
+		if resp == nil {
+			// Network-level failures (DNS, connection refused, timeout) never
+			// get an HTTP response to read.
+			logger.Error("dialing blaseball websocket", "error", err)
+		} else if body, readErr := ioutil.ReadAll(resp.Body); readErr != nil {
+			logger.Error("dialing blaseball websocket", "error", err, "body_read_error", readErr)
 		} else {
-			fmt.Println(string(body))
+			logger.Error("dialing blaseball websocket", "error", err, "response_status", resp.Status, "body", string(body))
 		}
 		return nil, err
 	}
@@ -262,7 +275,13 @@ func (c *BlaseballWebSocket) Close() error {
 	return c.conn.Close()
 }
 
-func (c *BlaseballWebSocket) NextUpdate() (interface{}, error) {
+// NextUpdate reads and decodes the next update off the raw connection.
+// logger receives a debug entry for every update, carrying update_type and
+// (for game data) season/day fields; a nil logger falls back to
+// slog.Default().
+func (c *BlaseballWebSocket) NextUpdate(logger *slog.Logger) (interface{}, error) {
+	logger = loggerOrDefault(logger)
+
 	// Get next raw update byte slice
 	t, msg, err := c.conn.ReadMessage()
 	if err != nil {
@@ -271,6 +290,45 @@ func (c *BlaseballWebSocket) NextUpdate() (interface{}, error) {
 		return nil, errors.New("unsupported websocket message type")
 	}
 
+	update, err := decodeSocketIOFrame(msg)
+	if err != nil {
+		decodeErrorsTotal.Inc()
+		logger.Debug("failed to decode websocket frame", "error", err)
+		return nil, err
+	}
+
+	logUpdate(logger, update)
+	return update, nil
+}
+
+// loggerOrDefault returns logger, or slog.Default() if it's nil.
+func loggerOrDefault(logger *slog.Logger) *slog.Logger {
+	if logger == nil {
+		return slog.Default()
+	}
+	return logger
+}
+
+// logUpdate emits a debug-level log entry for a decoded update, tagging it
+// with update_type and (for *GameData) season/day.
+func logUpdate(logger *slog.Logger, update interface{}) {
+	switch data := update.(type) {
+	case *LeagueData:
+		logger.Debug("received update", "update_type", "leagueData")
+	case *GameData:
+		season, day := -1, -1
+		if data.Sim != nil {
+			season, day = data.Sim.Season, data.Sim.Day
+		}
+		logger.Debug("received update", "update_type", "gameData", "season", season, "day", day)
+	}
+}
+
+// decodeSocketIOFrame decodes the payload of a Socket.IO "42[...]" event
+// frame into a *LeagueData or *GameData, depending on which event name
+// prefixes it. Split out of NextUpdate so Client can reuse it after handling
+// Engine.IO control frames (handshake, ping/pong) itself.
+func decodeSocketIOFrame(msg []byte) (interface{}, error) {
 	// Trim initial bytes
 	msg = bytes.TrimPrefix(msg, []byte("42["))
 
@@ -316,73 +374,124 @@ const (
 )
 
 func main() {
-	if len(os.Args) != 2 {
-		fmt.Printf("Usage: %s <cookie>\n", os.Args[0])
+	storeFlag := flag.String("store", "memory", "state store to use: memory, bolt:<path> or sqlite:<path>")
+	recordFlag := flag.String("record", "", "capture every raw websocket frame to this file")
+	replayFlag := flag.String("replay", "", "replay a --record capture instead of connecting live; no cookie needed")
+	speedFlag := flag.Float64("speed", 1, "replay speed multiplier (--replay only); 0 disables inter-frame delay")
+	listenFlag := flag.String("listen", "", "if set, serve the live state as a JSON/SSE/websocket API on this address, e.g. :8080")
+	jsonlFlag := flag.String("jsonl", "", "append every update as newline-delimited JSON to this file")
+	webhookFlag := flag.String("webhook", "", "POST every update as JSON to this URL")
+	webhookAuthFlag := flag.String("webhook-auth", "", "Authorization header value sent with --webhook requests")
+	webhookRetriesFlag := flag.Int("webhook-retries", 2, "additional attempts made after a failed --webhook POST")
+	webhookInsecureFlag := flag.Bool("webhook-insecure-skip-verify", false, "skip TLS certificate verification for --webhook")
+	flag.Parse()
+
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	slog.SetDefault(logger)
+
+	store, err := OpenStore(*storeFlag)
+	if err != nil {
+		logger.Error("opening store", "error", err)
 		os.Exit(1)
 	}
+	defer store.Close()
 
-	// Create maps
-	teamsMap := make(map[string]*Team)
-	subLeaguesMap := make(map[string]*SubLeague)
-	divisionsMap := make(map[string]*Division)
-	leaguesMap := make(map[string]*League)
+	bus := NewEventBus()
+	bus.Subscribe(newStoreSubscriber(store, logger))
+	bus.Subscribe(NewStdoutSubscriber(logger))
+	bus.Subscribe(metricsSubscriber{})
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	if *jsonlFlag != "" {
+		sink, err := NewJSONLSubscriber(*jsonlFlag, logger)
+		if err != nil {
+			logger.Error("opening jsonl sink", "error", err)
+			os.Exit(1)
+		}
+		defer sink.Close()
+		bus.Subscribe(sink)
+	}
 
-	fmt.Println("Connecting to blaseball websocket...")
-	conn, err := blaseballConnect(ctx, os.Args[1])
-	if err != nil {
-		fmt.Println(err)
-		return
+	if *webhookFlag != "" {
+		bus.Subscribe(NewWebhookSubscriber(*webhookFlag, *webhookAuthFlag, *webhookRetriesFlag, *webhookInsecureFlag, logger))
 	}
-	defer conn.Close()
-	fmt.Printf("Connected!\n\n")
 
-	signals := make(chan os.Signal)
+	if *listenFlag != "" {
+		api := NewAPIServer(logger)
+		bus.Subscribe(api)
+
+		go func() {
+			logger.Info("serving API", "address", *listenFlag)
+			if err := http.ListenAndServe(*listenFlag, api.Handler()); err != nil {
+				logger.Error("api server stopped", "error", err)
+			}
+		}()
+	}
+
+	signals := make(chan os.Signal, 1)
 	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGKILL)
 
+	var source UpdateSource
+
+	if *replayFlag != "" {
+		logger.Info("replaying capture", "path", *replayFlag, "speed", *speedFlag)
+		replay, err := NewReplaySource(*replayFlag, *speedFlag)
+		if err != nil {
+			logger.Error("opening replay source", "error", err)
+			os.Exit(1)
+		}
+		source = replay
+	} else {
+		if flag.NArg() != 1 {
+			fmt.Printf("Usage: %s [flags] <cookie>\n", os.Args[0])
+			flag.PrintDefaults()
+			os.Exit(1)
+		}
+		cookie := flag.Arg(0)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		logger.Info("connecting to blaseball websocket")
+		client, err := NewClient(ctx, cookie, logger)
+		if err != nil {
+			logger.Error("connecting", "error", err)
+			return
+		}
+		logger.Info("connected")
+
+		if *recordFlag != "" {
+			recorder, err := NewFrameRecorder(*recordFlag)
+			if err != nil {
+				logger.Error("opening record file", "error", err)
+				os.Exit(1)
+			}
+			defer recorder.Close()
+			client.SetRecorder(recorder)
+		}
+
+		source = client
+	}
+	defer source.Close()
+
 	go func() {
 		for {
-			fmt.Println("Waiting for update...")
-			update, err := conn.NextUpdate()
+			update, err := source.NextUpdate()
+			if err == io.EOF {
+				logger.Info("replay finished")
+				signals <- syscall.SIGTERM
+				return
+			}
 			if err != nil {
-				fmt.Println(err.Error() + "\n")
-				if websocket.IsCloseError(err) {
-					return
-				}
+				// Client reconnects transient failures on its own; just log
+				// and keep waiting for the next update.
+				logger.Error("reading update", "error", err)
 				continue
 			}
 
-			fmt.Printf("Update type: ")
-			switch update.(type) {
-			case *LeagueData:
-				fmt.Printf("LeagueData\n")
-				data := update.(*LeagueData)
-				for _, team := range data.Teams {
-					teamsMap[team.ID] = team
-				}
-				for _, subLeague := range data.SubLeagues {
-					subLeaguesMap[subLeague.ID] = subLeague
-				}
-				for _, division := range data.Divisions {
-					divisionsMap[division.ID] = division
-				}
-				for _, league := range data.Leagues {
-					leaguesMap[league.ID] = league
-				}
-
-			case *GameData:
-				fmt.Printf("GameData\n")
-
-			default:
-				fmt.Printf("unknown\n")
-			}
-
-			fmt.Println()
+			bus.Publish(update)
 		}
 	}()
 
 	sig := <-signals
-	fmt.Println("Signal received:", sig)
+	logger.Info("signal received", "signal", sig.String())
 }
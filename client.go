@@ -0,0 +1,371 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// ErrReadDeadlineExceeded is returned by Client.NextUpdate when no update
+// arrives before a deadline set with SetReadDeadline elapses.
+var ErrReadDeadlineExceeded = errors.New("blaseball: read deadline exceeded")
+
+const (
+	initialReconnectBackoff = 1 * time.Second
+	maxReconnectBackoff     = 30 * time.Second
+)
+
+// engineIOHandshake is the Engine.IO "0{...}" packet sent immediately after
+// the websocket upgrade, carrying the ping timing the server expects us to
+// honour.
+type engineIOHandshake struct {
+	SID          string   `json:"sid"`
+	Upgrades     []string `json:"upgrades"`
+	PingInterval int      `json:"pingInterval"`
+	PingTimeout  int      `json:"pingTimeout"`
+}
+
+var (
+	enginePingFrame = []byte("2")
+	enginePongFrame = []byte("3")
+)
+
+// isEngineIONonDataFrame reports whether msg is an Engine.IO/Socket.IO
+// control frame that decodeSocketIOFrame was never meant to parse: a bare
+// "3" pong, or a "40"/"41" namespace connect/disconnect frame. It does not
+// cover the "2" ping, which callers handle separately since it additionally
+// requires a pong reply. Shared by Client.readLoop and ReplaySource.NextUpdate
+// so a --record capture replays exactly as cleanly as the live connection.
+func isEngineIONonDataFrame(msg []byte) bool {
+	if bytes.Equal(msg, enginePongFrame) {
+		return true
+	}
+	return bytes.HasPrefix(msg, []byte("40")) || bytes.HasPrefix(msg, []byte("41"))
+}
+
+// update pairs a decoded update with any error hit producing it, so both can
+// travel together over Client.updates.
+type update struct {
+	value interface{}
+	err   error
+}
+
+// dialer opens a fresh BlaseballWebSocket. It's a field on Client (rather
+// than a direct call to blaseballConnect) so tests can substitute a fake
+// server.
+type dialer func(ctx context.Context) (*BlaseballWebSocket, error)
+
+// Client wraps BlaseballWebSocket with the Socket.IO/Engine.IO session
+// semantics the raw type ignores: it parses the initial handshake to learn
+// ping/pong timing, answers server pings with pongs, and reconnects
+// automatically with exponential backoff and jitter whenever the underlying
+// connection drops. Callers only ever see *LeagueData and *GameData updates
+// out of NextUpdate; reconnects happen transparently underneath.
+type Client struct {
+	dial   dialer
+	logger *slog.Logger
+
+	mu           sync.Mutex
+	ws           *BlaseballWebSocket
+	pingInterval time.Duration
+	pingTimeout  time.Duration
+
+	updates  chan update
+	pingStop chan struct{}
+
+	deadlineMu sync.Mutex
+	deadline   time.Duration
+
+	recorder *FrameRecorder
+}
+
+// SetRecorder attaches a FrameRecorder that every raw frame read off the
+// websocket (after the initial handshake) is written to before decoding.
+// Pass nil to stop recording.
+func (c *Client) SetRecorder(r *FrameRecorder) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.recorder = r
+}
+
+// NewClient connects to the blaseball websocket and starts the background
+// read-and-reconnect loop. The returned Client is safe for one goroutine to
+// call NextUpdate on while others call SetReadDeadline or Close. A nil
+// logger falls back to slog.Default().
+func NewClient(ctx context.Context, cookie string, logger *slog.Logger) (*Client, error) {
+	logger = loggerOrDefault(logger)
+
+	c := &Client{
+		dial: func(ctx context.Context) (*BlaseballWebSocket, error) {
+			return blaseballConnect(ctx, cookie, logger)
+		},
+		logger:  logger,
+		updates: make(chan update),
+	}
+
+	if err := c.connect(ctx); err != nil {
+		return nil, err
+	}
+
+	go c.readLoop(ctx)
+
+	return c, nil
+}
+
+// connect dials a fresh connection and reads its handshake, storing both the
+// connection and the ping timing it advertised, then starts a goroutine that
+// emits Engine.IO "2" ping frames on that timer so the server doesn't drop us
+// as idle after pingTimeout.
+func (c *Client) connect(ctx context.Context) error {
+	ws, err := c.dial(ctx)
+	if err != nil {
+		return err
+	}
+
+	pingInterval, pingTimeout, err := readHandshake(ws)
+	if err != nil {
+		ws.Close()
+		return err
+	}
+
+	stop := make(chan struct{})
+
+	c.mu.Lock()
+	c.ws = ws
+	c.pingInterval = pingInterval
+	c.pingTimeout = pingTimeout
+	if c.pingStop != nil {
+		close(c.pingStop)
+	}
+	c.pingStop = stop
+	c.mu.Unlock()
+
+	go c.pingLoop(ctx, ws, pingInterval, stop)
+
+	return nil
+}
+
+// pingLoop emits an Engine.IO ping frame on ws every interval until ctx is
+// cancelled, done is closed (a reconnect replaced ws), or a write fails.
+func (c *Client) pingLoop(ctx context.Context, ws *BlaseballWebSocket, interval time.Duration, done <-chan struct{}) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-done:
+			return
+		case <-ticker.C:
+			c.mu.Lock()
+			if c.ws != ws {
+				c.mu.Unlock()
+				return
+			}
+			err := ws.conn.WriteMessage(websocket.TextMessage, enginePingFrame)
+			c.mu.Unlock()
+			if err != nil {
+				c.logger.Debug("sending engine.io ping failed", "error", err)
+				return
+			}
+		}
+	}
+}
+
+// readHandshake reads the initial Engine.IO "0{...}" packet off a freshly
+// dialed connection and returns the ping interval/timeout it advertises.
+func readHandshake(ws *BlaseballWebSocket) (time.Duration, time.Duration, error) {
+	t, msg, err := ws.conn.ReadMessage()
+	if err != nil {
+		return 0, 0, err
+	}
+	if t != websocket.TextMessage {
+		return 0, 0, errors.New("unsupported websocket message type during handshake")
+	}
+
+	msg = bytes.TrimPrefix(msg, []byte("0"))
+
+	hs := &engineIOHandshake{}
+	if err := json.Unmarshal(msg, hs); err != nil {
+		return 0, 0, fmt.Errorf("decoding engine.io handshake: %w", err)
+	}
+
+	return time.Duration(hs.PingInterval) * time.Millisecond, time.Duration(hs.PingTimeout) * time.Millisecond, nil
+}
+
+// readLoop owns the current connection, feeding decoded updates into
+// c.updates and transparently reconnecting (with backoff) whenever a read
+// fails. It exits once ctx is cancelled.
+func (c *Client) readLoop(ctx context.Context) {
+	backoff := initialReconnectBackoff
+
+	for {
+		c.mu.Lock()
+		ws := c.ws
+		c.mu.Unlock()
+
+		t, msg, err := ws.conn.ReadMessage()
+		if err != nil {
+			c.logger.Error("websocket read failed", "error", err)
+			if !c.deliver(ctx, update{err: fmt.Errorf("websocket read: %w", err)}) {
+				return
+			}
+			if !c.reconnect(ctx, &backoff) {
+				return
+			}
+			continue
+		}
+
+		if t != websocket.TextMessage {
+			continue
+		}
+
+		c.mu.Lock()
+		recorder := c.recorder
+		c.mu.Unlock()
+		if recorder != nil {
+			if err := recorder.Record(msg); err != nil {
+				c.logger.Error("recording websocket frame", "error", err)
+			}
+		}
+
+		if bytes.Equal(msg, enginePingFrame) {
+			c.mu.Lock()
+			// Best-effort: if the pong write fails the next read will surface
+			// the broken connection and trigger a reconnect anyway.
+			_ = c.ws.conn.WriteMessage(websocket.TextMessage, enginePongFrame)
+			c.mu.Unlock()
+			continue
+		}
+
+		if isEngineIONonDataFrame(msg) {
+			continue
+		}
+
+		value, err := decodeSocketIOFrame(msg)
+		if err != nil {
+			decodeErrorsTotal.Inc()
+			c.logger.Debug("failed to decode websocket frame", "error", err)
+		} else {
+			logUpdate(c.logger, value)
+		}
+		if !c.deliver(ctx, update{value: value, err: err}) {
+			return
+		}
+	}
+}
+
+// deliver sends u to c.updates, returning false if ctx is cancelled first.
+func (c *Client) deliver(ctx context.Context, u update) bool {
+	select {
+	case c.updates <- u:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// reconnect closes the current connection and retries connect with
+// exponential backoff and jitter until it succeeds or ctx is cancelled.
+// backoff is updated in place so a later failure continues growing it rather
+// than restarting from initialReconnectBackoff.
+func (c *Client) reconnect(ctx context.Context, backoff *time.Duration) bool {
+	c.mu.Lock()
+	if c.ws != nil {
+		c.ws.Close()
+	}
+	c.mu.Unlock()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(jitter(*backoff)):
+		}
+
+		if err := c.connect(ctx); err != nil {
+			c.logger.Error("reconnect attempt failed", "error", err, "next_backoff", *backoff)
+			*backoff *= 2
+			if *backoff > maxReconnectBackoff {
+				*backoff = maxReconnectBackoff
+			}
+			continue
+		}
+
+		reconnectsTotal.Inc()
+		c.logger.Info("reconnected")
+		*backoff = initialReconnectBackoff
+		return true
+	}
+}
+
+// jitter returns a duration in [d/2, d), so a thundering herd of clients
+// reconnecting at once spreads out instead of retrying in lockstep.
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	half := int64(d / 2)
+	return time.Duration(half) + time.Duration(rand.Int63n(half+1))
+}
+
+// SetReadDeadline bounds how long the next call to NextUpdate will block
+// before returning ErrReadDeadlineExceeded. A zero duration (the default)
+// disables the deadline. It does not affect reconnection, which keeps
+// retrying in the background regardless.
+func (c *Client) SetReadDeadline(d time.Duration) {
+	c.deadlineMu.Lock()
+	defer c.deadlineMu.Unlock()
+	c.deadline = d
+}
+
+// NextUpdate blocks until a *LeagueData or *GameData update is decoded, the
+// read deadline set with SetReadDeadline elapses, or a read error occurs.
+// Unlike BlaseballWebSocket.NextUpdate, a dropped connection does not end the
+// stream: it's silently reconnected and the next call simply blocks a little
+// longer.
+func (c *Client) NextUpdate() (interface{}, error) {
+	c.deadlineMu.Lock()
+	d := c.deadline
+	c.deadlineMu.Unlock()
+
+	var deadlineCh <-chan struct{}
+	if d > 0 {
+		ch := make(chan struct{})
+		timer := time.AfterFunc(d, func() { close(ch) })
+		defer timer.Stop()
+		deadlineCh = ch
+	}
+
+	select {
+	case u := <-c.updates:
+		return u.value, u.err
+	case <-deadlineCh:
+		return nil, ErrReadDeadlineExceeded
+	}
+}
+
+// Close shuts down the current underlying connection. The background
+// read-and-reconnect loop should be stopped by cancelling the context passed
+// to NewClient; Close only releases the socket.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ws == nil {
+		return nil
+	}
+	return c.ws.Close()
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// fakeServer accepts a single websocket upgrade, sends an Engine.IO
+// handshake, then runs the scripted sequence of frames supplied via send().
+type fakeServer struct {
+	*httptest.Server
+	upgrader websocket.Upgrader
+	conns    chan *websocket.Conn
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	t.Helper()
+
+	fs := &fakeServer{conns: make(chan *websocket.Conn, 4)}
+	fs.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := fs.upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade: %v", err)
+			return
+		}
+
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(`0{"sid":"abc","upgrades":[],"pingInterval":25000,"pingTimeout":5000}`)); err != nil {
+			t.Errorf("write handshake: %v", err)
+			return
+		}
+
+		fs.conns <- conn
+	}))
+
+	return fs
+}
+
+func (fs *fakeServer) nextConn(t *testing.T) *websocket.Conn {
+	t.Helper()
+	select {
+	case conn := <-fs.conns:
+		return conn
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for client to connect")
+		return nil
+	}
+}
+
+func (fs *fakeServer) wsURL() string {
+	return "ws" + strings.TrimPrefix(fs.URL, "http")
+}
+
+func newTestClient(t *testing.T, fs *fakeServer) (*Client, context.CancelFunc) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	c := &Client{
+		dial: func(ctx context.Context) (*BlaseballWebSocket, error) {
+			conn, _, err := websocket.DefaultDialer.DialContext(ctx, fs.wsURL(), nil)
+			if err != nil {
+				return nil, err
+			}
+			return &BlaseballWebSocket{conn}, nil
+		},
+		logger:  slog.Default(),
+		updates: make(chan update),
+	}
+
+	if err := c.connect(ctx); err != nil {
+		cancel()
+		t.Fatalf("connect: %v", err)
+	}
+	go c.readLoop(ctx)
+
+	return c, cancel
+}
+
+func TestClientParsesHandshake(t *testing.T) {
+	fs := newFakeServer(t)
+	defer fs.Close()
+
+	c, cancel := newTestClient(t, fs)
+	defer cancel()
+	fs.nextConn(t)
+
+	if c.pingInterval != 25*time.Second {
+		t.Errorf("pingInterval = %v, want 25s", c.pingInterval)
+	}
+	if c.pingTimeout != 5*time.Second {
+		t.Errorf("pingTimeout = %v, want 5s", c.pingTimeout)
+	}
+}
+
+func TestClientAnswersPingWithPong(t *testing.T) {
+	fs := newFakeServer(t)
+	defer fs.Close()
+
+	c, cancel := newTestClient(t, fs)
+	defer cancel()
+	conn := fs.nextConn(t)
+
+	if c.pingInterval == 0 {
+		t.Fatalf("pingInterval = 0, want handshake value")
+	}
+
+	if err := conn.WriteMessage(websocket.TextMessage, []byte("2")); err != nil {
+		t.Fatalf("write ping: %v", err)
+	}
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, msg, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("read pong: %v", err)
+	}
+	if string(msg) != "3" {
+		t.Errorf("got frame %q, want pong frame %q", msg, "3")
+	}
+}
+
+func TestClientDecodesLeagueDataUpdate(t *testing.T) {
+	fs := newFakeServer(t)
+	defer fs.Close()
+
+	c, cancel := newTestClient(t, fs)
+	defer cancel()
+	conn := fs.nextConn(t)
+
+	frame := `42["leagueDataUpdate",{"teams":[{"_id":"t1","fullName":"Test Team"}],"subleagues":[],"divisions":[],"leagues":[]}]`
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(frame)); err != nil {
+		t.Fatalf("write update: %v", err)
+	}
+
+	c.SetReadDeadline(2 * time.Second)
+	got, err := c.NextUpdate()
+	if err != nil {
+		t.Fatalf("NextUpdate: %v", err)
+	}
+
+	leagueData, ok := got.(*LeagueData)
+	if !ok {
+		t.Fatalf("got %T, want *LeagueData", got)
+	}
+	if len(leagueData.Teams) != 1 || leagueData.Teams[0].ID != "t1" {
+		t.Errorf("unexpected teams: %+v", leagueData.Teams)
+	}
+}
+
+func TestClientReconnectsAfterDisconnect(t *testing.T) {
+	fs := newFakeServer(t)
+	defer fs.Close()
+
+	c, cancel := newTestClient(t, fs)
+	defer cancel()
+	first := fs.nextConn(t)
+
+	// Force the client to observe a read error.
+	first.Close()
+
+	c.SetReadDeadline(0) // backoff jitter can exceed a short deadline on the first attempt
+	errResult := make(chan error, 1)
+	go func() {
+		_, err := c.NextUpdate()
+		errResult <- err
+	}()
+
+	select {
+	case err := <-errResult:
+		if err == nil {
+			t.Fatal("expected a read error after the connection dropped")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the read error")
+	}
+
+	// The background loop should dial again.
+	second := fs.nextConn(t)
+	if second == first {
+		t.Fatal("expected a new connection, got the same one")
+	}
+}
+
+func TestNextUpdateRespectsReadDeadline(t *testing.T) {
+	fs := newFakeServer(t)
+	defer fs.Close()
+
+	c, cancel := newTestClient(t, fs)
+	defer cancel()
+	fs.nextConn(t)
+
+	c.SetReadDeadline(50 * time.Millisecond)
+	_, err := c.NextUpdate()
+	if err != ErrReadDeadlineExceeded {
+		t.Fatalf("err = %v, want ErrReadDeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"strings"
+	"sync"
+)
+
+// GameEvent is derived by diffing two consecutive Schedule snapshots for the
+// same game, capturing the kind of in-game moment that just happened (e.g. a
+// home run or a shame) rather than just the raw score/state fields.
+type GameEvent struct {
+	GameID    string `json:"gameId"`
+	Season    int    `json:"season"`
+	Day       int    `json:"day"`
+	Kind      string `json:"kind"`
+	HomeTeam  string `json:"homeTeam"`
+	AwayTeam  string `json:"awayTeam"`
+	HomeScore int    `json:"homeScore"`
+	AwayScore int    `json:"awayScore"`
+}
+
+// Event kinds produced by diffSchedules. EventScore/EventOut are the generic
+// fallbacks used when LastUpdate's free text doesn't match a more specific
+// kind like EventHomeRun/EventStrikeout.
+const (
+	EventScore     = "score"
+	EventOut       = "out"
+	EventHomeRun   = "home run"
+	EventStrikeout = "strikeout"
+	EventShame     = "shame"
+	EventGameStart = "game start"
+	EventGameOver  = "game over"
+)
+
+// Subscriber receives decoded updates from an EventBus. Implementations must
+// not block for long: a slow subscriber delays delivery to every other
+// subscriber on the same bus.
+type Subscriber interface {
+	OnLeagueData(data *LeagueData)
+	OnGameData(data *GameData)
+	OnGameEvent(event *GameEvent)
+}
+
+// EventBus dispatches decoded *LeagueData/*GameData updates, plus *GameEvent
+// values derived from diffing consecutive Schedule snapshots, to any number
+// of registered Subscribers. It replaces a single goroutine's switch
+// statement with a pub/sub model so new sinks (stdout, JSONL, webhooks, ...)
+// can be wired in without touching the read loop.
+type EventBus struct {
+	mu          sync.Mutex
+	subscribers []Subscriber
+	lastSched   map[string]*Schedule // gameID -> last seen snapshot, for diffing
+}
+
+// NewEventBus returns an EventBus with no subscribers registered.
+func NewEventBus() *EventBus {
+	return &EventBus{
+		lastSched: make(map[string]*Schedule),
+	}
+}
+
+// Subscribe registers s to receive every future update published on the bus.
+func (b *EventBus) Subscribe(s Subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers = append(b.subscribers, s)
+}
+
+// Publish dispatches update to every registered subscriber. update must be a
+// *LeagueData or *GameData, matching what Client.NextUpdate/BlaseballWebSocket.NextUpdate
+// produce; any other type is ignored.
+func (b *EventBus) Publish(u interface{}) {
+	switch data := u.(type) {
+	case *LeagueData:
+		b.publishLeagueData(data)
+	case *GameData:
+		b.publishGameData(data)
+	}
+}
+
+func (b *EventBus) publishLeagueData(data *LeagueData) {
+	b.mu.Lock()
+	subs := append([]Subscriber(nil), b.subscribers...)
+	b.mu.Unlock()
+
+	for _, s := range subs {
+		s.OnLeagueData(data)
+	}
+}
+
+func (b *EventBus) publishGameData(data *GameData) {
+	events := b.diffGameData(data)
+
+	b.mu.Lock()
+	subs := append([]Subscriber(nil), b.subscribers...)
+	b.mu.Unlock()
+
+	for _, s := range subs {
+		s.OnGameData(data)
+	}
+	for _, event := range events {
+		for _, s := range subs {
+			s.OnGameEvent(event)
+		}
+	}
+}
+
+// diffGameData compares each Schedule in data against the last snapshot seen
+// for that game ID and returns the GameEvents implied by what changed. The
+// new snapshots become the baseline for the next call.
+func (b *EventBus) diffGameData(data *GameData) []*GameEvent {
+	var events []*GameEvent
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, sched := range data.Schedules {
+		prev := b.lastSched[sched.ID]
+		b.lastSched[sched.ID] = sched
+
+		if prev == nil {
+			continue
+		}
+
+		events = append(events, diffSchedules(prev, sched)...)
+	}
+
+	return events
+}
+
+// scoreEventKind classifies a score increase using lastUpdate's free text,
+// falling back to the generic EventScore when it doesn't mention a home run.
+func scoreEventKind(lastUpdate string) string {
+	if strings.Contains(strings.ToLower(lastUpdate), "home run") {
+		return EventHomeRun
+	}
+	return EventScore
+}
+
+// outEventKind classifies an out using lastUpdate's free text, falling back
+// to the generic EventOut when it doesn't mention a strikeout.
+func outEventKind(lastUpdate string) string {
+	if strings.Contains(strings.ToLower(lastUpdate), "strikeout") {
+		return EventStrikeout
+	}
+	return EventOut
+}
+
+// diffSchedules compares two consecutive Schedule snapshots for the same
+// game and returns the GameEvents implied by the transition between them.
+func diffSchedules(prev, cur *Schedule) []*GameEvent {
+	var events []*GameEvent
+
+	base := func(kind string) *GameEvent {
+		return &GameEvent{
+			GameID:    cur.ID,
+			Season:    cur.Season,
+			Day:       cur.Day,
+			Kind:      kind,
+			HomeTeam:  cur.HomeTeam,
+			AwayTeam:  cur.AwayTeam,
+			HomeScore: cur.HomeScore,
+			AwayScore: cur.AwayScore,
+		}
+	}
+
+	if !prev.GameStart && cur.GameStart {
+		events = append(events, base(EventGameStart))
+	}
+
+	if cur.HomeScore > prev.HomeScore || cur.AwayScore > prev.AwayScore {
+		events = append(events, base(scoreEventKind(cur.LastUpdate)))
+	}
+
+	if cur.HalfInningOuts > prev.HalfInningOuts {
+		events = append(events, base(outEventKind(cur.LastUpdate)))
+	}
+
+	if !prev.Shame && cur.Shame {
+		events = append(events, base(EventShame))
+	}
+
+	if !prev.GameComplete && cur.GameComplete {
+		events = append(events, base(EventGameOver))
+	}
+
+	return events
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	updatesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "blaseball_scraper_updates_total",
+		Help: "Number of decoded updates received, labeled by update type.",
+	}, []string{"update_type"})
+
+	gameEventsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "blaseball_scraper_game_events_total",
+		Help: "Number of GameEvents derived from Schedule diffs, labeled by kind.",
+	}, []string{"kind"})
+
+	decodeErrorsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "blaseball_scraper_decode_errors_total",
+		Help: "Number of websocket frames that failed to decode.",
+	})
+
+	reconnectsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "blaseball_scraper_reconnects_total",
+		Help: "Number of times the client successfully reconnected after a dropped connection.",
+	})
+
+	teamShameRuns = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "blaseball_scraper_team_shame_runs",
+		Help: "Current shameRuns for a team.",
+	}, []string{"team"})
+
+	teamSeasonShames = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "blaseball_scraper_team_season_shames",
+		Help: "Current seasonShames for a team.",
+	}, []string{"team"})
+
+	teamChampionships = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "blaseball_scraper_team_championships",
+		Help: "Current championships for a team.",
+	}, []string{"team"})
+
+	gameHomeScore = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "blaseball_scraper_game_home_score",
+		Help: "Current home score for a game.",
+	}, []string{"homeTeam", "awayTeam"})
+
+	gameAwayScore = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "blaseball_scraper_game_away_score",
+		Help: "Current away score for a game.",
+	}, []string{"homeTeam", "awayTeam"})
+
+	gameInning = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "blaseball_scraper_game_inning",
+		Help: "Current inning for a game.",
+	}, []string{"homeTeam", "awayTeam"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		updatesTotal,
+		gameEventsTotal,
+		decodeErrorsTotal,
+		reconnectsTotal,
+		teamShameRuns,
+		teamSeasonShames,
+		teamChampionships,
+		gameHomeScore,
+		gameAwayScore,
+		gameInning,
+	)
+}
+
+// metricsSubscriber keeps the Prometheus gauges/counters above current. It's
+// registered on the EventBus alongside the other Subscribers; the actual
+// HTTP exposition happens at /metrics via promhttp, wired in APIServer.
+type metricsSubscriber struct{}
+
+func (metricsSubscriber) OnLeagueData(data *LeagueData) {
+	updatesTotal.WithLabelValues("leagueData").Inc()
+
+	for _, team := range data.Teams {
+		teamShameRuns.WithLabelValues(team.FullName).Set(float64(team.ShameRuns))
+		teamSeasonShames.WithLabelValues(team.FullName).Set(float64(team.SeasonShames))
+		teamChampionships.WithLabelValues(team.FullName).Set(float64(team.Championships))
+	}
+}
+
+func (metricsSubscriber) OnGameData(data *GameData) {
+	updatesTotal.WithLabelValues("gameData").Inc()
+
+	for _, sched := range data.Schedules {
+		labels := prometheus.Labels{"homeTeam": sched.HomeTeamName, "awayTeam": sched.AwayTeamName}
+		gameHomeScore.With(labels).Set(float64(sched.HomeScore))
+		gameAwayScore.With(labels).Set(float64(sched.AwayScore))
+		gameInning.With(labels).Set(float64(sched.Inning))
+	}
+}
+
+func (metricsSubscriber) OnGameEvent(event *GameEvent) {
+	gameEventsTotal.WithLabelValues(event.Kind).Inc()
+}
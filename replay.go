@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// UpdateSource is anything that can produce the decoded update stream main's
+// read loop consumes. *Client satisfies it live; *ReplaySource satisfies it
+// from a capture written by a FrameRecorder.
+type UpdateSource interface {
+	NextUpdate() (interface{}, error)
+	Close() error
+}
+
+// recordedFrame is one line of a --record capture file: a raw websocket text
+// frame plus how many milliseconds after the recording started it arrived.
+type recordedFrame struct {
+	OffsetMillis int64  `json:"offsetMillis"`
+	Frame        string `json:"frame"`
+}
+
+// FrameRecorder appends every raw websocket frame it's given to a
+// newline-delimited capture file, tagged with a monotonic offset from when
+// recording began so a later replay can honour the original inter-frame
+// delays.
+type FrameRecorder struct {
+	mu    sync.Mutex
+	file  *os.File
+	enc   *json.Encoder
+	start time.Time
+}
+
+// NewFrameRecorder creates (or truncates) path and returns a FrameRecorder
+// that writes to it.
+func NewFrameRecorder(path string) (*FrameRecorder, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("creating record file: %w", err)
+	}
+
+	return &FrameRecorder{
+		file:  f,
+		enc:   json.NewEncoder(f),
+		start: time.Now(),
+	}, nil
+}
+
+// Record appends frame to the capture file with its offset from the first
+// call to Record (or NewFrameRecorder, whichever came first).
+func (r *FrameRecorder) Record(frame []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.enc.Encode(recordedFrame{
+		OffsetMillis: time.Since(r.start).Milliseconds(),
+		Frame:        string(frame),
+	})
+}
+
+// Close closes the underlying capture file.
+func (r *FrameRecorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
+
+// ReplaySource reads a capture file written by a FrameRecorder back out as an
+// UpdateSource, sleeping between frames to honour the original inter-frame
+// delays (divided by speed, so speed > 1 replays faster than real time and
+// speed < 1 replays slower). A speed of 0 disables the delay entirely.
+type ReplaySource struct {
+	file       *os.File
+	dec        *json.Decoder
+	speed      float64
+	lastOffset int64
+}
+
+// NewReplaySource opens the capture file at path for replay.
+func NewReplaySource(path string, speed float64) (*ReplaySource, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening replay file: %w", err)
+	}
+
+	return &ReplaySource{
+		file:  f,
+		dec:   json.NewDecoder(f),
+		speed: speed,
+	}, nil
+}
+
+// NextUpdate reads the next recorded frame, sleeps for its share of the
+// original inter-frame delay, and decodes it. Engine.IO/Socket.IO control
+// frames (ping, pong, namespace connect/disconnect) are skipped the same way
+// Client.readLoop skips them live, since there's no live connection to pong
+// back to and decodeSocketIOFrame was never meant to parse them. It returns
+// io.EOF once the capture file is exhausted.
+func (r *ReplaySource) NextUpdate() (interface{}, error) {
+	for {
+		var rf recordedFrame
+		if err := r.dec.Decode(&rf); err != nil {
+			if err == io.EOF {
+				return nil, io.EOF
+			}
+			return nil, fmt.Errorf("decoding replay frame: %w", err)
+		}
+
+		delay := time.Duration(rf.OffsetMillis-r.lastOffset) * time.Millisecond
+		r.lastOffset = rf.OffsetMillis
+		if r.speed > 0 {
+			delay = time.Duration(float64(delay) / r.speed)
+		}
+		if delay > 0 {
+			time.Sleep(delay)
+		}
+
+		frame := []byte(rf.Frame)
+		if bytes.Equal(frame, enginePingFrame) || isEngineIONonDataFrame(frame) {
+			continue
+		}
+
+		return decodeSocketIOFrame(frame)
+	}
+}
+
+// Close closes the underlying capture file.
+func (r *ReplaySource) Close() error {
+	return r.file.Close()
+}
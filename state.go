@@ -0,0 +1,50 @@
+package main
+
+import "log/slog"
+
+// storeSubscriber forwards decoded updates into a Store, taking over the job
+// the old teamsMap/subLeaguesMap/divisionsMap/leaguesMap globals (and,
+// briefly, mapSubscriber) used to do directly in memory.
+type storeSubscriber struct {
+	store  Store
+	logger *slog.Logger
+}
+
+// newStoreSubscriber returns a storeSubscriber writing through to store. A
+// nil logger falls back to slog.Default().
+func newStoreSubscriber(store Store, logger *slog.Logger) *storeSubscriber {
+	return &storeSubscriber{store: store, logger: loggerOrDefault(logger)}
+}
+
+func (s *storeSubscriber) OnLeagueData(data *LeagueData) {
+	for _, team := range data.Teams {
+		if err := s.store.UpsertTeam(team); err != nil {
+			s.logger.Error("store: upsert team", "error", err, "team", team.ID)
+		}
+	}
+	for _, subLeague := range data.SubLeagues {
+		if err := s.store.UpsertSubLeague(subLeague); err != nil {
+			s.logger.Error("store: upsert subleague", "error", err, "subleague", subLeague.ID)
+		}
+	}
+	for _, division := range data.Divisions {
+		if err := s.store.UpsertDivision(division); err != nil {
+			s.logger.Error("store: upsert division", "error", err, "division", division.ID)
+		}
+	}
+	for _, league := range data.Leagues {
+		if err := s.store.UpsertLeague(league); err != nil {
+			s.logger.Error("store: upsert league", "error", err, "league", league.ID)
+		}
+	}
+}
+
+func (s *storeSubscriber) OnGameData(data *GameData) {
+	for _, sched := range data.Schedules {
+		if err := s.store.UpsertGame(sched); err != nil {
+			s.logger.Error("store: upsert game", "error", err, "game", sched.ID)
+		}
+	}
+}
+
+func (s *storeSubscriber) OnGameEvent(event *GameEvent) {}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Store persists the entities decoded off the websocket so callers don't
+// have to keep their own teamsMap/subLeaguesMap/divisionsMap/leaguesMap.
+// Unlike those maps, a Store also archives every Schedule update it sees
+// (rather than overwriting the previous one), so historical state can be
+// queried back out with SnapshotAt.
+type Store interface {
+	UpsertTeam(team *Team) error
+	UpsertSubLeague(subLeague *SubLeague) error
+	UpsertDivision(division *Division) error
+	UpsertLeague(league *League) error
+	// UpsertGame records sched as the current state of its game and archives
+	// it as a new point in that game's time series.
+	UpsertGame(sched *Schedule) error
+
+	GetTeam(id string) (*Team, bool, error)
+	// ListGames returns the current state of every game on the given season
+	// and day.
+	ListGames(season, day int) ([]*Schedule, error)
+	// SnapshotAt returns, for every game, the most recent archived Schedule
+	// update at or before t.
+	SnapshotAt(t time.Time) ([]*Schedule, error)
+
+	Close() error
+}
+
+// OpenStore parses a --store flag value and opens the corresponding driver.
+// Recognised forms are "memory" (the default), "bolt:<path>" and
+// "sqlite:<path>", e.g. "sqlite:./blaseball.db".
+func OpenStore(spec string) (Store, error) {
+	if spec == "" || spec == "memory" {
+		return NewMemoryStore(), nil
+	}
+
+	driver, path, ok := strings.Cut(spec, ":")
+	if !ok {
+		return nil, fmt.Errorf("store: invalid spec %q, want <driver>:<path>", spec)
+	}
+
+	switch driver {
+	case "bolt":
+		return NewBoltStore(path)
+	case "sqlite":
+		return NewSQLiteStore(path)
+	default:
+		return nil, fmt.Errorf("store: unknown driver %q", driver)
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	teamsBucket       = []byte("teams")
+	subLeaguesBucket  = []byte("subleagues")
+	divisionsBucket   = []byte("divisions")
+	leaguesBucket     = []byte("leagues")
+	gamesBucket       = []byte("games")        // gameID -> latest Schedule JSON
+	gameHistoryBucket = []byte("game_history") // "season/day/gameID/updateTimeRFC3339Nano" -> Schedule JSON
+)
+
+// BoltStore is the BoltDB-backed Store driver. Each entity type gets its own
+// top-level bucket, keyed by ID; every Schedule update is additionally
+// archived under gameHistoryBucket so history isn't lost on overwrite.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// NewBoltStore opens (creating if necessary) a BoltDB file at path and
+// ensures all buckets this driver needs exist.
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("opening bolt store: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{teamsBucket, subLeaguesBucket, divisionsBucket, leaguesBucket, gamesBucket, gameHistoryBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("initializing bolt store: %w", err)
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+func boltPut(db *bolt.DB, bucket []byte, key string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).Put([]byte(key), data)
+	})
+}
+
+func (s *BoltStore) UpsertTeam(team *Team) error { return boltPut(s.db, teamsBucket, team.ID, team) }
+
+func (s *BoltStore) UpsertSubLeague(subLeague *SubLeague) error {
+	return boltPut(s.db, subLeaguesBucket, subLeague.ID, subLeague)
+}
+
+func (s *BoltStore) UpsertDivision(division *Division) error {
+	return boltPut(s.db, divisionsBucket, division.ID, division)
+}
+
+func (s *BoltStore) UpsertLeague(league *League) error {
+	return boltPut(s.db, leaguesBucket, league.ID, league)
+}
+
+func (s *BoltStore) UpsertGame(sched *Schedule) error {
+	if err := boltPut(s.db, gamesBucket, sched.ID, sched); err != nil {
+		return err
+	}
+
+	historyKey := fmt.Sprintf("%d/%d/%s/%s", sched.Season, sched.Day, sched.ID, time.Now().Format(time.RFC3339Nano))
+	return boltPut(s.db, gameHistoryBucket, historyKey, sched)
+}
+
+func (s *BoltStore) GetTeam(id string) (*Team, bool, error) {
+	var team *Team
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(teamsBucket).Get([]byte(id))
+		if data == nil {
+			return nil
+		}
+		team = &Team{}
+		return json.Unmarshal(data, team)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return team, team != nil, nil
+}
+
+func (s *BoltStore) ListGames(season, day int) ([]*Schedule, error) {
+	var games []*Schedule
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(gamesBucket).ForEach(func(_, data []byte) error {
+			sched := &Schedule{}
+			if err := json.Unmarshal(data, sched); err != nil {
+				return err
+			}
+			if sched.Season == season && sched.Day == day {
+				games = append(games, sched)
+			}
+			return nil
+		})
+	})
+
+	return games, err
+}
+
+// SnapshotAt scans the history bucket, keeping the most recent update at or
+// before t for each game. Key order isn't relied on for recency: the trailing
+// RFC3339Nano timestamp is parsed and compared directly, since
+// time.Format(RFC3339Nano) trims trailing fractional zeros and so doesn't
+// always sort lexically the same as chronologically (e.g. "...00.5Z" sorts
+// after "...00.53Z" despite being earlier).
+func (s *BoltStore) SnapshotAt(t time.Time) ([]*Schedule, error) {
+	best := make(map[string]*Schedule)
+	bestAt := make(map[string]time.Time)
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(gameHistoryBucket).ForEach(func(key, data []byte) error {
+			at, err := updateTimeFromHistoryKey(string(key))
+			if err != nil || at.After(t) {
+				return nil
+			}
+
+			sched := &Schedule{}
+			if err := json.Unmarshal(data, sched); err != nil {
+				return err
+			}
+
+			if prevAt, ok := bestAt[sched.ID]; ok && !at.After(prevAt) {
+				return nil
+			}
+
+			best[sched.ID] = sched
+			bestAt[sched.ID] = at
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	games := make([]*Schedule, 0, len(best))
+	for _, sched := range best {
+		games = append(games, sched)
+	}
+	return games, nil
+}
+
+// updateTimeFromHistoryKey extracts the trailing RFC3339Nano timestamp from a
+// "season/day/gameID/updateTime" history key.
+func updateTimeFromHistoryKey(key string) (time.Time, error) {
+	idx := len(key)
+	for i := len(key) - 1; i >= 0; i-- {
+		if key[i] == '/' {
+			idx = i + 1
+			break
+		}
+	}
+	return time.Parse(time.RFC3339Nano, key[idx:])
+}
+
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// gamePoint is one archived Schedule update, timestamped at the moment the
+// store saw it.
+type gamePoint struct {
+	at    time.Time
+	sched *Schedule
+}
+
+// MemoryStore is the in-memory Store driver: the same behavior the old
+// teamsMap/subLeaguesMap/divisionsMap/leaguesMap globals gave main, plus a
+// per-game history slice so SnapshotAt works without a real database.
+type MemoryStore struct {
+	mu sync.RWMutex
+
+	teams      map[string]*Team
+	subLeagues map[string]*SubLeague
+	divisions  map[string]*Division
+	leagues    map[string]*League
+
+	latest  map[string]*Schedule // gameID -> current state
+	history map[string][]gamePoint
+}
+
+// NewMemoryStore returns an empty in-memory Store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		teams:      make(map[string]*Team),
+		subLeagues: make(map[string]*SubLeague),
+		divisions:  make(map[string]*Division),
+		leagues:    make(map[string]*League),
+		latest:     make(map[string]*Schedule),
+		history:    make(map[string][]gamePoint),
+	}
+}
+
+func (s *MemoryStore) UpsertTeam(team *Team) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.teams[team.ID] = team
+	return nil
+}
+
+func (s *MemoryStore) UpsertSubLeague(subLeague *SubLeague) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subLeagues[subLeague.ID] = subLeague
+	return nil
+}
+
+func (s *MemoryStore) UpsertDivision(division *Division) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.divisions[division.ID] = division
+	return nil
+}
+
+func (s *MemoryStore) UpsertLeague(league *League) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.leagues[league.ID] = league
+	return nil
+}
+
+func (s *MemoryStore) UpsertGame(sched *Schedule) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latest[sched.ID] = sched
+	s.history[sched.ID] = append(s.history[sched.ID], gamePoint{at: time.Now(), sched: sched})
+	return nil
+}
+
+func (s *MemoryStore) GetTeam(id string) (*Team, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	team, ok := s.teams[id]
+	return team, ok, nil
+}
+
+func (s *MemoryStore) ListGames(season, day int) ([]*Schedule, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var games []*Schedule
+	for _, sched := range s.latest {
+		if sched.Season == season && sched.Day == day {
+			games = append(games, sched)
+		}
+	}
+
+	sort.Slice(games, func(i, j int) bool { return games[i].ID < games[j].ID })
+	return games, nil
+}
+
+func (s *MemoryStore) SnapshotAt(t time.Time) ([]*Schedule, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var games []*Schedule
+	for _, points := range s.history {
+		var best *Schedule
+		for _, p := range points {
+			if p.at.After(t) {
+				break
+			}
+			best = p.sched
+		}
+		if best != nil {
+			games = append(games, best)
+		}
+	}
+
+	sort.Slice(games, func(i, j int) bool { return games[i].ID < games[j].ID })
+	return games, nil
+}
+
+func (s *MemoryStore) Close() error { return nil }
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS teams (
+	id   TEXT PRIMARY KEY,
+	data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS subleagues (
+	id   TEXT PRIMARY KEY,
+	data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS divisions (
+	id   TEXT PRIMARY KEY,
+	data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS leagues (
+	id   TEXT PRIMARY KEY,
+	data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS games (
+	id   TEXT PRIMARY KEY,
+	season INTEGER NOT NULL,
+	day    INTEGER NOT NULL,
+	data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS game_history (
+	season      INTEGER NOT NULL,
+	day         INTEGER NOT NULL,
+	game_id     TEXT NOT NULL,
+	update_time INTEGER NOT NULL, -- UnixNano; see SnapshotAt for why not RFC3339Nano text
+	data        TEXT NOT NULL,
+	PRIMARY KEY (season, day, game_id, update_time)
+);
+`
+
+// SQLiteStore is the SQLite-backed Store driver. Team/SubLeague/Division/
+// League are kept as a simple ID->JSON table each, matching the in-memory
+// maps they replace. Games get both a "current state" table and a
+// game_history table keyed by (season, day, gameID, updateTime) so every
+// Schedule update is archived instead of overwritten.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore opens (creating if necessary) a SQLite database at path and
+// applies sqliteSchema.
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, fmt.Errorf("opening sqlite store: %w", err)
+	}
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("initializing sqlite schema: %w", err)
+	}
+
+	return &SQLiteStore{db: db}, nil
+}
+
+func sqliteUpsert(db *sql.DB, table string, id string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(fmt.Sprintf(`INSERT INTO %s (id, data) VALUES (?, ?) ON CONFLICT(id) DO UPDATE SET data = excluded.data`, table), id, string(data))
+	return err
+}
+
+func (s *SQLiteStore) UpsertTeam(team *Team) error { return sqliteUpsert(s.db, "teams", team.ID, team) }
+
+func (s *SQLiteStore) UpsertSubLeague(subLeague *SubLeague) error {
+	return sqliteUpsert(s.db, "subleagues", subLeague.ID, subLeague)
+}
+
+func (s *SQLiteStore) UpsertDivision(division *Division) error {
+	return sqliteUpsert(s.db, "divisions", division.ID, division)
+}
+
+func (s *SQLiteStore) UpsertLeague(league *League) error {
+	return sqliteUpsert(s.db, "leagues", league.ID, league)
+}
+
+func (s *SQLiteStore) UpsertGame(sched *Schedule) error {
+	data, err := json.Marshal(sched)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(`INSERT INTO games (id, season, day, data) VALUES (?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET season = excluded.season, day = excluded.day, data = excluded.data`,
+		sched.ID, sched.Season, sched.Day, string(data))
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(`INSERT INTO game_history (season, day, game_id, update_time, data) VALUES (?, ?, ?, ?, ?)`,
+		sched.Season, sched.Day, sched.ID, time.Now().UnixNano(), string(data))
+	return err
+}
+
+func (s *SQLiteStore) GetTeam(id string) (*Team, bool, error) {
+	var data string
+	err := s.db.QueryRow(`SELECT data FROM teams WHERE id = ?`, id).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	team := &Team{}
+	if err := json.Unmarshal([]byte(data), team); err != nil {
+		return nil, false, err
+	}
+	return team, true, nil
+}
+
+func (s *SQLiteStore) ListGames(season, day int) ([]*Schedule, error) {
+	rows, err := s.db.Query(`SELECT data FROM games WHERE season = ? AND day = ?`, season, day)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanSchedules(rows)
+}
+
+// SnapshotAt returns, for every game, the most recent game_history row at or
+// before t. The correlated MAX(update_time) subquery picks that row per
+// game_id without needing a separate round trip. update_time is stored as
+// UnixNano rather than RFC3339Nano text: time.Format(RFC3339Nano) trims
+// trailing fractional zeros, so a TEXT column sorts "...00.5Z" after
+// "...00.53Z" despite it being the earlier instant, making both MAX() and the
+// <= comparison here unreliable.
+func (s *SQLiteStore) SnapshotAt(t time.Time) ([]*Schedule, error) {
+	rows, err := s.db.Query(`
+		SELECT h.data FROM game_history h
+		INNER JOIN (
+			SELECT game_id, MAX(update_time) AS update_time
+			FROM game_history
+			WHERE update_time <= ?
+			GROUP BY game_id
+		) latest ON latest.game_id = h.game_id AND latest.update_time = h.update_time
+	`, t.UnixNano())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanSchedules(rows)
+}
+
+func scanSchedules(rows *sql.Rows) ([]*Schedule, error) {
+	var games []*Schedule
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		sched := &Schedule{}
+		if err := json.Unmarshal([]byte(data), sched); err != nil {
+			return nil, err
+		}
+		games = append(games, sched)
+	}
+	return games, rows.Err()
+}
+
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}